@@ -0,0 +1,158 @@
+// Copyright 2015 CoreOS, Inc. All rights reserved.
+// Copyright 2014 Dropbox, Inc. All rights reserved.
+// Use of this source code is governed by the BSD 3-Clause license,
+// which can be found in the LICENSE file.
+
+package sqlbuilder
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// alterAction is a single clause of an ALTER TABLE statement, e.g.
+// "ADD COLUMN `foo` INTEGER NOT NULL".
+type alterAction func(d Dialect, out *bytes.Buffer) error
+
+// AlterTableStatement builds an ALTER TABLE statement out of a sequence
+// of actions, applied in the order they were added.
+type AlterTableStatement struct {
+	table   string
+	actions []alterAction
+}
+
+// NewAlterTableStatement returns an AlterTableStatement that alters the
+// given table.
+func NewAlterTableStatement(table string) *AlterTableStatement {
+	return &AlterTableStatement{table: table}
+}
+
+// AddColumn appends an ADD COLUMN action. column must also implement
+// DefinableColumn (every column type does except alias and
+// deferred-lookup columns); if it doesn't, ToSql reports the mismatch
+// as an error rather than failing to compile, since callers routinely
+// hold columns as the narrower NonAliasColumn (e.g. the result of a
+// Must*Column constructor).
+func (s *AlterTableStatement) AddColumn(column NonAliasColumn) *AlterTableStatement {
+	s.actions = append(s.actions, func(d Dialect, out *bytes.Buffer) error {
+		definable, ok := column.(DefinableColumn)
+		if !ok {
+			return fmt.Errorf("column `%s` (%T) cannot appear in an ADD COLUMN action", column.Name(), column)
+		}
+		out.WriteString("ADD COLUMN ")
+		return definable.SerializeColumnDefinition(d, out)
+	})
+	return s
+}
+
+// DropColumn appends a DROP COLUMN action.
+func (s *AlterTableStatement) DropColumn(name string) *AlterTableStatement {
+	s.actions = append(s.actions, func(d Dialect, out *bytes.Buffer) error {
+		if !validIdentifierName(name) {
+			return errors.New("invalid column name in DROP COLUMN: " + name)
+		}
+		out.WriteString("DROP COLUMN ")
+		writeEscapedIdentifier(d, name, out)
+		return nil
+	})
+	return s
+}
+
+// ChangeColumn appends a CHANGE COLUMN action, renaming oldName to the
+// new column's name and redefining it in one step. column must also
+// implement DefinableColumn; see AddColumn.
+func (s *AlterTableStatement) ChangeColumn(oldName string, column NonAliasColumn) *AlterTableStatement {
+	s.actions = append(s.actions, func(d Dialect, out *bytes.Buffer) error {
+		if !validIdentifierName(oldName) {
+			return errors.New("invalid column name in CHANGE COLUMN: " + oldName)
+		}
+		definable, ok := column.(DefinableColumn)
+		if !ok {
+			return fmt.Errorf("column `%s` (%T) cannot appear in a CHANGE COLUMN action", column.Name(), column)
+		}
+		out.WriteString("CHANGE COLUMN ")
+		writeEscapedIdentifier(d, oldName, out)
+		out.WriteByte(' ')
+		return definable.SerializeColumnDefinition(d, out)
+	})
+	return s
+}
+
+// AddIndex appends an ADD INDEX action over the given columns.
+func (s *AlterTableStatement) AddIndex(name string, cols ...NonAliasColumn) *AlterTableStatement {
+	s.actions = append(s.actions, func(d Dialect, out *bytes.Buffer) error {
+		out.WriteString("ADD INDEX ")
+		writeEscapedIdentifier(d, name, out)
+		out.WriteString(" (")
+		if err := serializeColumnNameList(cols, d, out); err != nil {
+			return err
+		}
+		out.WriteByte(')')
+		return nil
+	})
+	return s
+}
+
+// AddPrimaryKey appends an ADD PRIMARY KEY action over the given columns.
+func (s *AlterTableStatement) AddPrimaryKey(cols ...NonAliasColumn) *AlterTableStatement {
+	s.actions = append(s.actions, func(d Dialect, out *bytes.Buffer) error {
+		out.WriteString("ADD PRIMARY KEY (")
+		if err := serializeColumnNameList(cols, d, out); err != nil {
+			return err
+		}
+		out.WriteByte(')')
+		return nil
+	})
+	return s
+}
+
+// AddForeignKey appends an ADD FOREIGN KEY action referencing
+// referencedTable(referencedColumns...).
+func (s *AlterTableStatement) AddForeignKey(
+	columns []NonAliasColumn,
+	referencedTable string,
+	referencedColumns ...NonAliasColumn) *AlterTableStatement {
+
+	s.actions = append(s.actions, func(d Dialect, out *bytes.Buffer) error {
+		out.WriteString("ADD FOREIGN KEY (")
+		if err := serializeColumnNameList(columns, d, out); err != nil {
+			return err
+		}
+		out.WriteString(") REFERENCES ")
+		writeEscapedIdentifier(d, referencedTable, out)
+		out.WriteString(" (")
+		if err := serializeColumnNameList(referencedColumns, d, out); err != nil {
+			return err
+		}
+		out.WriteByte(')')
+		return nil
+	})
+	return s
+}
+
+// ToSql serializes the statement using the given dialect.
+func (s *AlterTableStatement) ToSql(d Dialect) (sql string, err error) {
+	if !validIdentifierName(s.table) {
+		return "", errors.New("invalid table name in ALTER TABLE: " + s.table)
+	}
+	if len(s.actions) == 0 {
+		return "", errors.New("ALTER TABLE must have at least one action")
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("ALTER TABLE ")
+	writeEscapedIdentifier(d, s.table, &buf)
+	buf.WriteByte(' ')
+
+	for i, action := range s.actions {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		if err := action(d, &buf); err != nil {
+			return "", err
+		}
+	}
+
+	return buf.String(), nil
+}
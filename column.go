@@ -65,6 +65,16 @@ type baseColumn struct {
 	name     string
 	nullable NullableColumn
 	table    string
+
+	// DDL-only attributes. These are ignored by the query-serialization
+	// paths above and only consulted by SerializeColumnDefinition.
+	size          int
+	precision     int
+	scale         int
+	hasDefault    bool
+	defaultValue  interface{}
+	autoIncrement bool
+	primaryKey    bool
 }
 
 func (c *baseColumn) Name() string {
@@ -76,16 +86,69 @@ func (c *baseColumn) setTableName(table string) error {
 	return nil
 }
 
+// ColumnOption customizes the DDL attributes of a column at construction
+// time (size/precision, default value, auto-increment, primary key). It
+// has no effect on query serialization.
+type ColumnOption func(*baseColumn)
+
+// Size sets the column's length/width (e.g. the `n` in VARCHAR(n) or
+// VARBINARY(n)).
+func Size(n int) ColumnOption {
+	return func(c *baseColumn) {
+		c.size = n
+	}
+}
+
+// Precision sets the precision and scale used by DECIMAL/DOUBLE columns.
+func Precision(precision, scale int) ColumnOption {
+	return func(c *baseColumn) {
+		c.precision = precision
+		c.scale = scale
+	}
+}
+
+// Default sets the column's DDL default value.
+func Default(value interface{}) ColumnOption {
+	return func(c *baseColumn) {
+		c.hasDefault = true
+		c.defaultValue = value
+	}
+}
+
+// AutoIncrement marks the column as auto-incrementing.
+func AutoIncrement() ColumnOption {
+	return func(c *baseColumn) {
+		c.autoIncrement = true
+	}
+}
+
+// PrimaryKey marks the column as the table's primary key, emitting an
+// inline "PRIMARY KEY" on the column's own definition. Applying it to
+// several columns emits one inline clause per column rather than a
+// composite key; use CreateTableStatement.AddPrimaryKey for that. This
+// is mutually exclusive with CreateTableStatement.AddPrimaryKey, which
+// emits a separate table-level PRIMARY KEY clause instead: using both
+// for the same table is rejected by ToSql, since most dialects only
+// allow one PRIMARY KEY clause per table.
+func PrimaryKey() ColumnOption {
+	return func(c *baseColumn) {
+		c.primaryKey = true
+	}
+}
+
+// hasPrimaryKeyOption reports whether PrimaryKey() was applied to this
+// column, for CreateTableStatement.ToSql to detect when it's combined
+// with AddPrimaryKey.
+func (c *baseColumn) hasPrimaryKeyOption() bool {
+	return c.primaryKey
+}
+
 func (c *baseColumn) SerializeSqlForColumnList(includeTableName bool, d Dialect, out *bytes.Buffer) error {
 	if c.table != "" && includeTableName {
-		out.WriteRune(d.EscapeCharacter())
-		out.WriteString(c.table)
-		out.WriteRune(d.EscapeCharacter())
+		writeEscapedIdentifier(d, c.table, out)
 		out.WriteByte('.')
 	}
-	out.WriteRune(d.EscapeCharacter())
-	out.WriteString(c.name)
-	out.WriteRune(d.EscapeCharacter())
+	writeEscapedIdentifier(d, c.name, out)
 	return nil
 }
 
@@ -98,16 +161,79 @@ type bytesColumn struct {
 	isExpression
 }
 
-// Representation of VARBINARY/BLOB columns
-// This function will panic if name is not valid
-func BytesColumn(name string, nullable NullableColumn) NonAliasColumn {
-	if !validIdentifierName(name) {
-		panic("Invalid column name in bytes column")
+// Representation of VARBINARY/BLOB columns.
+// Returns an error if name is not a valid identifier.
+func BytesColumn(name string, nullable NullableColumn, options ...ColumnOption) (NonAliasColumn, error) {
+	normalized, err := NormalizeIdentifier(name)
+	if err != nil {
+		return nil, err
 	}
 	bc := &bytesColumn{}
-	bc.name = name
+	bc.name = normalized
 	bc.nullable = nullable
-	return bc
+	for _, opt := range options {
+		opt(&bc.baseColumn)
+	}
+	return bc, nil
+}
+
+// MustBytesColumn is like BytesColumn, but panics instead of returning
+// an error if name is not a valid identifier.
+func MustBytesColumn(name string, nullable NullableColumn, options ...ColumnOption) NonAliasColumn {
+	col, err := BytesColumn(name, nullable, options...)
+	if err != nil {
+		panic(err)
+	}
+	return col
+}
+
+// SerializeColumnDefinition writes the DDL column definition for this
+// column (e.g. "`data` VARBINARY(255) NOT NULL") to out.
+func (c *bytesColumn) SerializeColumnDefinition(d Dialect, out *bytes.Buffer) error {
+	return serializeColumnDefinition(&c.baseColumn, d.BytesTypeName(c.size), d, out)
+}
+
+// serializeColumnDefinition writes a column definition consisting of just
+// a name, a type, and the shared suffix (nullability/default/auto
+// increment/primary key). Columns that need to interleave extra clauses
+// (e.g. stringColumn's CHARACTER SET/COLLATE) call writeColumnNameAndType
+// and writeColumnDefinitionSuffix directly instead.
+func serializeColumnDefinition(c *baseColumn, typeName string, d Dialect, out *bytes.Buffer) error {
+	if err := writeColumnNameAndType(c, typeName, d, out); err != nil {
+		return err
+	}
+	return writeColumnDefinitionSuffix(c, d, out)
+}
+
+// writeColumnNameAndType writes the escaped column name and its type.
+// The name was already validated by NormalizeIdentifier when the column
+// was constructed, so Quoted(...) names are accepted here as well.
+func writeColumnNameAndType(c *baseColumn, typeName string, d Dialect, out *bytes.Buffer) error {
+	writeEscapedIdentifier(d, c.name, out)
+	out.WriteByte(' ')
+	out.WriteString(typeName)
+	return nil
+}
+
+func writeColumnDefinitionSuffix(c *baseColumn, d Dialect, out *bytes.Buffer) error {
+	if c.nullable {
+		out.WriteString(" NULL")
+	} else {
+		out.WriteString(" NOT NULL")
+	}
+	if c.hasDefault {
+		fmt.Fprintf(out, " DEFAULT %s", d.LiteralEncode(c.defaultValue))
+	}
+	if c.autoIncrement {
+		if keyword := d.AutoIncrementKeyword(); keyword != "" {
+			out.WriteString(" ")
+			out.WriteString(keyword)
+		}
+	}
+	if c.primaryKey {
+		out.WriteString(" PRIMARY KEY")
+	}
+	return nil
 }
 
 type stringColumn struct {
@@ -117,21 +243,64 @@ type stringColumn struct {
 	collation Collation
 }
 
-// Representation of VARCHAR/TEXT columns
-// This function will panic if name is not valid
+// Representation of VARCHAR/TEXT columns.
+// Returns an error if name is not a valid identifier.
 func StrColumn(
 	name string,
 	charset Charset,
 	collation Collation,
-	nullable NullableColumn) NonAliasColumn {
+	nullable NullableColumn,
+	options ...ColumnOption) (NonAliasColumn, error) {
 
-	if !validIdentifierName(name) {
-		panic("Invalid column name in str column")
+	normalized, err := NormalizeIdentifier(name)
+	if err != nil {
+		return nil, err
 	}
 	sc := &stringColumn{charset: charset, collation: collation}
-	sc.name = name
+	sc.name = normalized
 	sc.nullable = nullable
-	return sc
+	for _, opt := range options {
+		opt(&sc.baseColumn)
+	}
+	return sc, nil
+}
+
+// MustStrColumn is like StrColumn, but panics instead of returning an
+// error if name is not a valid identifier.
+func MustStrColumn(
+	name string,
+	charset Charset,
+	collation Collation,
+	nullable NullableColumn,
+	options ...ColumnOption) NonAliasColumn {
+
+	col, err := StrColumn(name, charset, collation, nullable, options...)
+	if err != nil {
+		panic(err)
+	}
+	return col
+}
+
+// SerializeColumnDefinition writes the DDL column definition for this
+// column (e.g. "`name` VARCHAR(255) CHARACTER SET utf8 COLLATE utf8_bin
+// NOT NULL" on MySQL, `"name" VARCHAR(255) NOT NULL` on Postgres, or
+// `"name" TEXT NOT NULL` on SQLite) to out. Charset/Collation are
+// MySQL-specific vocabulary: MySQL's CHARACTER SET/COLLATE clause has no
+// equivalent per-column syntax on Postgres or SQLite, so it's only
+// emitted on MySQL.
+func (c *stringColumn) SerializeColumnDefinition(d Dialect, out *bytes.Buffer) error {
+	if err := writeColumnNameAndType(&c.baseColumn, d.StringTypeName(c.size), d, out); err != nil {
+		return err
+	}
+	if _, ok := d.(MySQLDialect); ok {
+		if c.charset != "" {
+			fmt.Fprintf(out, " CHARACTER SET %s", c.charset)
+		}
+		if c.collation != "" {
+			fmt.Fprintf(out, " COLLATE %s", c.collation)
+		}
+	}
+	return writeColumnDefinitionSuffix(&c.baseColumn, d, out)
 }
 
 type dateTimeColumn struct {
@@ -139,16 +308,36 @@ type dateTimeColumn struct {
 	isExpression
 }
 
-// Representation of DateTime columns
-// This function will panic if name is not valid
-func DateTimeColumn(name string, nullable NullableColumn) NonAliasColumn {
-	if !validIdentifierName(name) {
-		panic("Invalid column name in datetime column")
+// Representation of DateTime columns.
+// Returns an error if name is not a valid identifier.
+func DateTimeColumn(name string, nullable NullableColumn, options ...ColumnOption) (NonAliasColumn, error) {
+	normalized, err := NormalizeIdentifier(name)
+	if err != nil {
+		return nil, err
 	}
 	dc := &dateTimeColumn{}
-	dc.name = name
+	dc.name = normalized
 	dc.nullable = nullable
-	return dc
+	for _, opt := range options {
+		opt(&dc.baseColumn)
+	}
+	return dc, nil
+}
+
+// MustDateTimeColumn is like DateTimeColumn, but panics instead of
+// returning an error if name is not a valid identifier.
+func MustDateTimeColumn(name string, nullable NullableColumn, options ...ColumnOption) NonAliasColumn {
+	col, err := DateTimeColumn(name, nullable, options...)
+	if err != nil {
+		panic(err)
+	}
+	return col
+}
+
+// SerializeColumnDefinition writes the DDL column definition for this
+// column (e.g. "`created_at` DATETIME NOT NULL") to out.
+func (c *dateTimeColumn) SerializeColumnDefinition(d Dialect, out *bytes.Buffer) error {
+	return serializeColumnDefinition(&c.baseColumn, d.DateTimeTypeName(), d, out)
 }
 
 type integerColumn struct {
@@ -156,16 +345,46 @@ type integerColumn struct {
 	isExpression
 }
 
-// Representation of any integer column
-// This function will panic if name is not valid
-func IntColumn(name string, nullable NullableColumn) NonAliasColumn {
-	if !validIdentifierName(name) {
-		panic("Invalid column name in int column")
+// Representation of any integer column.
+// Returns an error if name is not a valid identifier.
+func IntColumn(name string, nullable NullableColumn, options ...ColumnOption) (NonAliasColumn, error) {
+	normalized, err := NormalizeIdentifier(name)
+	if err != nil {
+		return nil, err
 	}
 	ic := &integerColumn{}
-	ic.name = name
+	ic.name = normalized
 	ic.nullable = nullable
-	return ic
+	for _, opt := range options {
+		opt(&ic.baseColumn)
+	}
+	return ic, nil
+}
+
+// MustIntColumn is like IntColumn, but panics instead of returning an
+// error if name is not a valid identifier.
+func MustIntColumn(name string, nullable NullableColumn, options ...ColumnOption) NonAliasColumn {
+	col, err := IntColumn(name, nullable, options...)
+	if err != nil {
+		panic(err)
+	}
+	return col
+}
+
+// SerializeColumnDefinition writes the DDL column definition for this
+// column (e.g. "`id` INTEGER NOT NULL AUTO_INCREMENT PRIMARY KEY" on
+// MySQL, or `"id" SERIAL NOT NULL PRIMARY KEY` on Postgres, which has no
+// bare AUTO_INCREMENT keyword and instead folds it into the type) to out.
+func (c *integerColumn) SerializeColumnDefinition(d Dialect, out *bytes.Buffer) error {
+	typeName := d.IntegerTypeName()
+	base := c.baseColumn
+	if base.autoIncrement {
+		if serialType := d.IntegerAutoIncrementTypeName(); serialType != "" {
+			typeName = serialType
+			base.autoIncrement = false
+		}
+	}
+	return serializeColumnDefinition(&base, typeName, d, out)
 }
 
 type doubleColumn struct {
@@ -173,16 +392,76 @@ type doubleColumn struct {
 	isExpression
 }
 
-// Representation of any double column
-// This function will panic if name is not valid
-func DoubleColumn(name string, nullable NullableColumn) NonAliasColumn {
-	if !validIdentifierName(name) {
-		panic("Invalid column name in int column")
+// Representation of any double column.
+// Returns an error if name is not a valid identifier.
+func DoubleColumn(name string, nullable NullableColumn, options ...ColumnOption) (NonAliasColumn, error) {
+	normalized, err := NormalizeIdentifier(name)
+	if err != nil {
+		return nil, err
 	}
 	ic := &doubleColumn{}
-	ic.name = name
+	ic.name = normalized
 	ic.nullable = nullable
-	return ic
+	for _, opt := range options {
+		opt(&ic.baseColumn)
+	}
+	return ic, nil
+}
+
+// MustDoubleColumn is like DoubleColumn, but panics instead of
+// returning an error if name is not a valid identifier.
+func MustDoubleColumn(name string, nullable NullableColumn, options ...ColumnOption) NonAliasColumn {
+	col, err := DoubleColumn(name, nullable, options...)
+	if err != nil {
+		panic(err)
+	}
+	return col
+}
+
+// SerializeColumnDefinition writes the DDL column definition for this
+// column (e.g. "`score` DOUBLE(8,2) NOT NULL") to out.
+func (c *doubleColumn) SerializeColumnDefinition(d Dialect, out *bytes.Buffer) error {
+	return serializeColumnDefinition(&c.baseColumn, d.DoubleTypeName(c.precision, c.scale), d, out)
+}
+
+type decimalColumn struct {
+	baseColumn
+	isExpression
+}
+
+// Representation of DECIMAL/NUMERIC columns with fixed precision and
+// scale, e.g. DecimalColumn("price", 10, 2, NotNullable).
+// Returns an error if name is not a valid identifier.
+func DecimalColumn(name string, precision, scale int, nullable NullableColumn, options ...ColumnOption) (NonAliasColumn, error) {
+	normalized, err := NormalizeIdentifier(name)
+	if err != nil {
+		return nil, err
+	}
+	dc := &decimalColumn{}
+	dc.name = normalized
+	dc.nullable = nullable
+	dc.precision = precision
+	dc.scale = scale
+	for _, opt := range options {
+		opt(&dc.baseColumn)
+	}
+	return dc, nil
+}
+
+// MustDecimalColumn is like DecimalColumn, but panics instead of
+// returning an error if name is not a valid identifier.
+func MustDecimalColumn(name string, precision, scale int, nullable NullableColumn, options ...ColumnOption) NonAliasColumn {
+	col, err := DecimalColumn(name, precision, scale, nullable, options...)
+	if err != nil {
+		panic(err)
+	}
+	return col
+}
+
+// SerializeColumnDefinition writes the DDL column definition for this
+// column (e.g. "`price` DECIMAL(10,2) NOT NULL") to out.
+func (c *decimalColumn) SerializeColumnDefinition(d Dialect, out *bytes.Buffer) error {
+	return serializeColumnDefinition(&c.baseColumn, d.DecimalTypeName(c.precision, c.scale), d, out)
 }
 
 type booleanColumn struct {
@@ -193,16 +472,37 @@ type booleanColumn struct {
 	// the deferred lookup equivalent can never be isBoolExpression)
 }
 
-// Representation of TINYINT used as a bool
-// This function will panic if name is not valid
-func BoolColumn(name string, nullable NullableColumn) NonAliasColumn {
-	if !validIdentifierName(name) {
-		panic("Invalid column name in bool column")
+// Representation of boolean columns (TINYINT(1) on MySQL, BOOLEAN on
+// Postgres and SQLite).
+// Returns an error if name is not a valid identifier.
+func BoolColumn(name string, nullable NullableColumn, options ...ColumnOption) (NonAliasColumn, error) {
+	normalized, err := NormalizeIdentifier(name)
+	if err != nil {
+		return nil, err
 	}
 	bc := &booleanColumn{}
-	bc.name = name
+	bc.name = normalized
 	bc.nullable = nullable
-	return bc
+	for _, opt := range options {
+		opt(&bc.baseColumn)
+	}
+	return bc, nil
+}
+
+// MustBoolColumn is like BoolColumn, but panics instead of returning an
+// error if name is not a valid identifier.
+func MustBoolColumn(name string, nullable NullableColumn, options ...ColumnOption) NonAliasColumn {
+	col, err := BoolColumn(name, nullable, options...)
+	if err != nil {
+		panic(err)
+	}
+	return col
+}
+
+// SerializeColumnDefinition writes the DDL column definition for this
+// column (e.g. "`active` TINYINT(1) NOT NULL") to out.
+func (c *booleanColumn) SerializeColumnDefinition(d Dialect, out *bytes.Buffer) error {
+	return serializeColumnDefinition(&c.baseColumn, d.BooleanTypeName(), d, out)
 }
 
 type aliasColumn struct {
@@ -211,9 +511,7 @@ type aliasColumn struct {
 }
 
 func (c *aliasColumn) SerializeSql(d Dialect, out *bytes.Buffer) error {
-	out.WriteRune(d.EscapeCharacter())
-	out.WriteString(c.name)
-	out.WriteRune(d.EscapeCharacter())
+	writeEscapedIdentifier(d, c.name, out)
 	return nil
 }
 
@@ -237,9 +535,7 @@ func (c *aliasColumn) SerializeSqlForColumnList(includeTableName bool, d Dialect
 		return err
 	}
 	out.WriteString(") AS ")
-	out.WriteRune(d.EscapeCharacter())
-	out.WriteString(c.name)
-	out.WriteRune(d.EscapeCharacter())
+	writeEscapedIdentifier(d, c.name, out)
 	return nil
 }
 
@@ -247,12 +543,30 @@ func (c *aliasColumn) setTableName(table string) error {
 	return fmt.Errorf("alias column '%s' should never have setTableName called on it", c.name)
 }
 
-// Representation of aliased clauses (expression AS name)
-func Alias(name string, c Expression) Column {
+// Representation of aliased clauses (expression AS name).
+// Returns an error if name is not a valid identifier or c is nil.
+func Alias(name string, c Expression) (Column, error) {
+	normalized, err := NormalizeIdentifier(name)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil {
+		return nil, errors.New("cannot alias a nil expression")
+	}
 	ac := &aliasColumn{}
-	ac.name = name
+	ac.name = normalized
 	ac.expression = c
-	return ac
+	return ac, nil
+}
+
+// MustAlias is like Alias, but panics instead of returning an error if
+// name is not a valid identifier or c is nil.
+func MustAlias(name string, c Expression) Column {
+	col, err := Alias(name, c)
+	if err != nil {
+		panic(err)
+	}
+	return col
 }
 
 // This is a strict subset of the actual allowed identifiers
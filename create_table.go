@@ -0,0 +1,228 @@
+// Copyright 2015 CoreOS, Inc. All rights reserved.
+// Copyright 2014 Dropbox, Inc. All rights reserved.
+// Use of this source code is governed by the BSD 3-Clause license,
+// which can be found in the LICENSE file.
+
+package sqlbuilder
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// DefinableColumn is implemented by every column type that can appear in
+// a CREATE TABLE/ALTER TABLE column list (i.e. everything except alias
+// and deferred-lookup columns).
+type DefinableColumn interface {
+	NonAliasColumn
+	SerializeColumnDefinition(d Dialect, out *bytes.Buffer) error
+}
+
+// foreignKeyDef describes a single FOREIGN KEY constraint.
+type foreignKeyDef struct {
+	columns          []NonAliasColumn
+	referencedTable  string
+	referencedColumn []NonAliasColumn
+}
+
+// indexDef describes a single (non-primary) index.
+type indexDef struct {
+	name    string
+	columns []NonAliasColumn
+}
+
+// CreateTableStatement builds a CREATE TABLE statement from a set of
+// column definitions, indexes, and constraints.
+type CreateTableStatement struct {
+	table       string
+	ifNotExists bool
+	columns     []NonAliasColumn
+	primaryKey  []NonAliasColumn
+	indexes     []indexDef
+	foreignKeys []foreignKeyDef
+	engine      string
+}
+
+// NewCreateTableStatement returns a CreateTableStatement that builds the
+// given table.
+func NewCreateTableStatement(table string) *CreateTableStatement {
+	return &CreateTableStatement{table: table}
+}
+
+// IfNotExists adds an IF NOT EXISTS clause to the statement.
+func (s *CreateTableStatement) IfNotExists() *CreateTableStatement {
+	s.ifNotExists = true
+	return s
+}
+
+// AddColumn adds a column to the table being created. column must also
+// implement DefinableColumn (every column type does except alias and
+// deferred-lookup columns); if it doesn't, ToSql reports the mismatch as
+// an error rather than failing to compile, since callers routinely hold
+// columns as the narrower NonAliasColumn (e.g. the result of a
+// Must*Column constructor).
+func (s *CreateTableStatement) AddColumn(column NonAliasColumn) *CreateTableStatement {
+	s.columns = append(s.columns, column)
+	return s
+}
+
+// AddPrimaryKey declares the table's primary key as the given columns,
+// emitting a separate table-level PRIMARY KEY clause. This is mutually
+// exclusive with the per-column PrimaryKey() option: using both for the
+// same table is rejected by ToSql, since most dialects only allow one
+// PRIMARY KEY clause per table.
+func (s *CreateTableStatement) AddPrimaryKey(cols ...NonAliasColumn) *CreateTableStatement {
+	s.primaryKey = append(s.primaryKey, cols...)
+	return s
+}
+
+// primaryKeyOptionColumn is implemented by every column type built on
+// baseColumn (i.e. everything except deferred-lookup columns), letting
+// ToSql detect whether the per-column PrimaryKey() option was used
+// alongside AddPrimaryKey.
+type primaryKeyOptionColumn interface {
+	hasPrimaryKeyOption() bool
+}
+
+// AddIndex adds a secondary index over the given columns.
+func (s *CreateTableStatement) AddIndex(name string, cols ...NonAliasColumn) *CreateTableStatement {
+	s.indexes = append(s.indexes, indexDef{name: name, columns: cols})
+	return s
+}
+
+// AddForeignKey adds a FOREIGN KEY constraint referencing
+// referencedTable(referencedColumns...).
+func (s *CreateTableStatement) AddForeignKey(
+	columns []NonAliasColumn,
+	referencedTable string,
+	referencedColumns ...NonAliasColumn) *CreateTableStatement {
+
+	s.foreignKeys = append(s.foreignKeys, foreignKeyDef{
+		columns:          columns,
+		referencedTable:  referencedTable,
+		referencedColumn: referencedColumns,
+	})
+	return s
+}
+
+// Engine sets the storage engine clause (e.g. "InnoDB"). Dialects that
+// don't support storage engines ignore this.
+func (s *CreateTableStatement) Engine(engine string) *CreateTableStatement {
+	s.engine = engine
+	return s
+}
+
+// ToSql serializes the statement using the given dialect.
+func (s *CreateTableStatement) ToSql(d Dialect) (sql string, err error) {
+	if !validIdentifierName(s.table) {
+		return "", errors.New("invalid table name in CREATE TABLE: " + s.table)
+	}
+	if len(s.columns) == 0 {
+		return "", errors.New("CREATE TABLE must have at least one column")
+	}
+	if d.IsReservedWord(s.table) {
+		return "", fmt.Errorf("`%s` is a reserved word in this dialect", s.table)
+	}
+	for _, col := range s.columns {
+		if d.IsReservedWord(col.Name()) {
+			return "", fmt.Errorf("`%s` is a reserved word in this dialect", col.Name())
+		}
+	}
+	if len(s.primaryKey) > 0 {
+		for _, col := range s.columns {
+			if pk, ok := col.(primaryKeyOptionColumn); ok && pk.hasPrimaryKeyOption() {
+				return "", fmt.Errorf(
+					"column `%s` uses the per-column PrimaryKey() option and the table also calls AddPrimaryKey; use only one",
+					col.Name())
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("CREATE TABLE ")
+	if s.ifNotExists {
+		buf.WriteString("IF NOT EXISTS ")
+	}
+	writeEscapedIdentifier(d, s.table, &buf)
+	buf.WriteString(" (\n")
+
+	first := true
+	writeSep := func() {
+		if !first {
+			buf.WriteString(",\n")
+		}
+		first = false
+	}
+
+	for _, col := range s.columns {
+		definable, ok := col.(DefinableColumn)
+		if !ok {
+			return "", fmt.Errorf("column `%s` (%T) cannot appear in a CREATE TABLE column list", col.Name(), col)
+		}
+		writeSep()
+		buf.WriteString("  ")
+		if err := definable.SerializeColumnDefinition(d, &buf); err != nil {
+			return "", err
+		}
+	}
+
+	if len(s.primaryKey) > 0 {
+		writeSep()
+		buf.WriteString("  PRIMARY KEY (")
+		if err := serializeColumnNameList(s.primaryKey, d, &buf); err != nil {
+			return "", err
+		}
+		buf.WriteByte(')')
+	}
+
+	for _, idx := range s.indexes {
+		writeSep()
+		buf.WriteString("  INDEX ")
+		writeEscapedIdentifier(d, idx.name, &buf)
+		buf.WriteString(" (")
+		if err := serializeColumnNameList(idx.columns, d, &buf); err != nil {
+			return "", err
+		}
+		buf.WriteByte(')')
+	}
+
+	for _, fk := range s.foreignKeys {
+		writeSep()
+		buf.WriteString("  FOREIGN KEY (")
+		if err := serializeColumnNameList(fk.columns, d, &buf); err != nil {
+			return "", err
+		}
+		buf.WriteString(") REFERENCES ")
+		writeEscapedIdentifier(d, fk.referencedTable, &buf)
+		buf.WriteString(" (")
+		if err := serializeColumnNameList(fk.referencedColumn, d, &buf); err != nil {
+			return "", err
+		}
+		buf.WriteByte(')')
+	}
+
+	buf.WriteString("\n)")
+
+	if _, isMySQL := d.(MySQLDialect); isMySQL && s.engine != "" {
+		buf.WriteString(" ENGINE=")
+		buf.WriteString(s.engine)
+	}
+
+	return buf.String(), nil
+}
+
+// serializeColumnNameList writes a comma-separated, escaped list of
+// column names (no table qualifier) for use in PRIMARY KEY/INDEX/FOREIGN
+// KEY clauses.
+func serializeColumnNameList(cols []NonAliasColumn, d Dialect, out *bytes.Buffer) error {
+	for i, col := range cols {
+		if i > 0 {
+			out.WriteString(", ")
+		}
+		if err := col.SerializeSqlForColumnList(false, d, out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
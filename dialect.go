@@ -0,0 +1,410 @@
+// Copyright 2015 CoreOS, Inc. All rights reserved.
+// Copyright 2014 Dropbox, Inc. All rights reserved.
+// Use of this source code is governed by the BSD 3-Clause license,
+// which can be found in the LICENSE file.
+
+package sqlbuilder
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Dialect captures the differences in SQL syntax between database
+// backends: identifier quoting, the column-type vocabulary used when
+// generating DDL (see SerializeColumnDefinition), literal encoding, and
+// placeholder/LIMIT-OFFSET style.
+type Dialect interface {
+	// EscapeCharacter returns the rune used to quote identifiers
+	// (backtick for MySQL, double-quote for Postgres/SQLite).
+	EscapeCharacter() rune
+
+	// FoldIdentifierCase applies this dialect's case-folding rule to an
+	// unquoted identifier before it's quoted and written out: Postgres
+	// folds unquoted identifiers to lowercase, MySQL and SQLite preserve
+	// the case as written.
+	FoldIdentifierCase(name string) string
+
+	// Placeholder returns the bound-parameter placeholder for the
+	// index'th (0-based) parameter in a statement, e.g. "?" for MySQL
+	// and SQLite, "$1", "$2", ... for Postgres.
+	Placeholder(index int) string
+
+	// BooleanLiteral returns the literal used for the given boolean
+	// value, e.g. "1"/"0" for MySQL, "TRUE"/"FALSE" for Postgres.
+	BooleanLiteral(value bool) string
+
+	// LiteralEncode renders a Go value as a SQL literal suitable for
+	// use in a DEFAULT clause.
+	LiteralEncode(value interface{}) string
+
+	// LimitOffset renders the LIMIT/OFFSET clause (or dialect
+	// equivalent) for the given limit and offset. offset < 0 means no
+	// OFFSET clause.
+	LimitOffset(limit, offset int64) string
+
+	// Column-type vocabulary used by SerializeColumnDefinition.
+	BytesTypeName(size int) string
+	StringTypeName(size int) string
+	DateTimeTypeName() string
+	IntegerTypeName() string
+	DoubleTypeName(precision, scale int) string
+	DecimalTypeName(precision, scale int) string
+	BooleanTypeName() string
+	AutoIncrementKeyword() string
+
+	// IntegerAutoIncrementTypeName returns the type name that should
+	// replace IntegerTypeName() for an auto-incrementing integer column
+	// when the dialect expresses auto-increment as part of the type
+	// itself (e.g. Postgres's "SERIAL") rather than via
+	// AutoIncrementKeyword(). Dialects that use AutoIncrementKeyword()
+	// instead return "".
+	IntegerAutoIncrementTypeName() string
+
+	JSONTypeName() string
+	UUIDTypeName() string
+	ArrayTypeName(elementType string) string
+
+	// UUIDLiteral renders the 16-byte normalized form of a UUID (see
+	// NormalizeUUIDValue) as a literal matching this dialect's
+	// UUIDTypeName storage: a hex byte-string literal for MySQL's
+	// BINARY(16), or the canonical "xxxxxxxx-xxxx-..." string form for
+	// Postgres's native UUID type and SQLite's TEXT.
+	UUIDLiteral(id []byte) string
+
+	// IsReservedWord reports whether name (already case-folded by the
+	// caller as appropriate) collides with one of this dialect's
+	// reserved words.
+	IsReservedWord(name string) bool
+
+	// JSONExtract renders an expression that extracts the value at path
+	// (e.g. "$.a.b") out of the already-serialized column reference
+	// columnSQL, e.g. "JSON_EXTRACT(`doc`, '$.a.b')" on MySQL or
+	// `"doc"->'a'->'b'` on Postgres.
+	JSONExtract(columnSQL, path string) string
+}
+
+// MySQLDialect implements Dialect for MySQL/MariaDB.
+type MySQLDialect struct{}
+
+// MySQL returns the MySQL/MariaDB dialect.
+func MySQL() Dialect {
+	return MySQLDialect{}
+}
+
+func (MySQLDialect) EscapeCharacter() rune { return '`' }
+
+func (MySQLDialect) FoldIdentifierCase(name string) string { return name }
+
+func (MySQLDialect) Placeholder(index int) string { return "?" }
+
+func (MySQLDialect) BooleanLiteral(value bool) string {
+	if value {
+		return "1"
+	}
+	return "0"
+}
+
+func (d MySQLDialect) LiteralEncode(value interface{}) string {
+	return encodeLiteral(d, value)
+}
+
+func (MySQLDialect) LimitOffset(limit, offset int64) string {
+	return formatLimitOffset(limit, offset)
+}
+
+func (MySQLDialect) BytesTypeName(size int) string {
+	if size <= 0 {
+		return "BLOB"
+	}
+	return "VARBINARY(" + strconv.Itoa(size) + ")"
+}
+
+func (MySQLDialect) StringTypeName(size int) string {
+	if size <= 0 {
+		return "TEXT"
+	}
+	return "VARCHAR(" + strconv.Itoa(size) + ")"
+}
+
+func (MySQLDialect) DateTimeTypeName() string { return "DATETIME" }
+
+func (MySQLDialect) IntegerTypeName() string { return "INTEGER" }
+
+func (MySQLDialect) DoubleTypeName(precision, scale int) string {
+	return formatPrecisionType("DOUBLE", precision, scale)
+}
+
+func (MySQLDialect) DecimalTypeName(precision, scale int) string {
+	return formatPrecisionType("DECIMAL", precision, scale)
+}
+
+func (MySQLDialect) BooleanTypeName() string { return "TINYINT(1)" }
+
+func (MySQLDialect) AutoIncrementKeyword() string { return "AUTO_INCREMENT" }
+
+func (MySQLDialect) IntegerAutoIncrementTypeName() string { return "" }
+
+func (MySQLDialect) JSONTypeName() string { return "JSON" }
+
+func (MySQLDialect) UUIDTypeName() string { return "BINARY(16)" }
+
+func (MySQLDialect) UUIDLiteral(id []byte) string { return "X'" + hex.EncodeToString(id) + "'" }
+
+func (MySQLDialect) ArrayTypeName(elementType string) string {
+	// MySQL has no native array type; callers typically fall back to a
+	// JSON column instead.
+	return "JSON"
+}
+
+func (MySQLDialect) JSONExtract(columnSQL, path string) string {
+	return "JSON_EXTRACT(" + columnSQL + ", '" + path + "')"
+}
+
+func (MySQLDialect) IsReservedWord(name string) bool {
+	return mysqlReservedWords[strings.ToLower(name)]
+}
+
+// mysqlReservedWords is a representative (not exhaustive) set of MySQL
+// reserved words that would otherwise silently produce broken DDL if
+// used bare as an identifier.
+var mysqlReservedWords = map[string]bool{
+	"select": true, "insert": true, "update": true, "delete": true,
+	"from": true, "where": true, "table": true, "index": true,
+	"key": true, "primary": true, "foreign": true, "order": true,
+	"group": true, "by": true, "limit": true, "column": true,
+}
+
+// PostgresDialect implements Dialect for PostgreSQL.
+type PostgresDialect struct{}
+
+// Postgres returns the PostgreSQL dialect.
+func Postgres() Dialect {
+	return PostgresDialect{}
+}
+
+func (PostgresDialect) EscapeCharacter() rune { return '"' }
+
+func (PostgresDialect) FoldIdentifierCase(name string) string { return strings.ToLower(name) }
+
+func (PostgresDialect) Placeholder(index int) string {
+	return "$" + strconv.Itoa(index+1)
+}
+
+func (PostgresDialect) BooleanLiteral(value bool) string {
+	if value {
+		return "TRUE"
+	}
+	return "FALSE"
+}
+
+func (d PostgresDialect) LiteralEncode(value interface{}) string {
+	return encodeLiteral(d, value)
+}
+
+func (PostgresDialect) LimitOffset(limit, offset int64) string {
+	return formatLimitOffset(limit, offset)
+}
+
+func (PostgresDialect) BytesTypeName(size int) string { return "BYTEA" }
+
+func (PostgresDialect) StringTypeName(size int) string {
+	if size <= 0 {
+		return "TEXT"
+	}
+	return "VARCHAR(" + strconv.Itoa(size) + ")"
+}
+
+func (PostgresDialect) DateTimeTypeName() string { return "TIMESTAMP" }
+
+func (PostgresDialect) IntegerTypeName() string { return "INTEGER" }
+
+func (PostgresDialect) DoubleTypeName(precision, scale int) string {
+	return "DOUBLE PRECISION"
+}
+
+func (PostgresDialect) DecimalTypeName(precision, scale int) string {
+	return formatPrecisionType("NUMERIC", precision, scale)
+}
+
+func (PostgresDialect) BooleanTypeName() string { return "BOOLEAN" }
+
+func (PostgresDialect) AutoIncrementKeyword() string { return "" }
+
+// IntegerAutoIncrementTypeName returns "SERIAL": Postgres has no bare
+// AUTO_INCREMENT keyword, so auto-incrementing integer columns are
+// instead declared with the SERIAL pseudo-type.
+func (PostgresDialect) IntegerAutoIncrementTypeName() string { return "SERIAL" }
+
+func (PostgresDialect) JSONTypeName() string { return "JSONB" }
+
+func (PostgresDialect) UUIDTypeName() string { return "UUID" }
+
+func (PostgresDialect) UUIDLiteral(id []byte) string {
+	return "'" + uuidStringFromBytes(id) + "'"
+}
+
+func (PostgresDialect) ArrayTypeName(elementType string) string {
+	return elementType + "[]"
+}
+
+func (PostgresDialect) JSONExtract(columnSQL, path string) string {
+	return columnSQL + jsonPathToArrows(path)
+}
+
+func (PostgresDialect) IsReservedWord(name string) bool {
+	return postgresReservedWords[strings.ToLower(name)]
+}
+
+// postgresReservedWords is a representative (not exhaustive) set of
+// Postgres reserved words that would otherwise silently produce broken
+// DDL if used bare as an identifier.
+var postgresReservedWords = map[string]bool{
+	"select": true, "insert": true, "update": true, "delete": true,
+	"from": true, "where": true, "table": true, "user": true,
+	"order": true, "group": true, "by": true, "limit": true,
+	"column": true, "primary": true, "foreign": true, "references": true,
+}
+
+// SQLiteDialect implements Dialect for SQLite.
+type SQLiteDialect struct{}
+
+// SQLite returns the SQLite dialect.
+func SQLite() Dialect {
+	return SQLiteDialect{}
+}
+
+func (SQLiteDialect) EscapeCharacter() rune { return '"' }
+
+func (SQLiteDialect) FoldIdentifierCase(name string) string { return name }
+
+func (SQLiteDialect) Placeholder(index int) string { return "?" }
+
+func (SQLiteDialect) BooleanLiteral(value bool) string {
+	if value {
+		return "1"
+	}
+	return "0"
+}
+
+func (d SQLiteDialect) LiteralEncode(value interface{}) string {
+	return encodeLiteral(d, value)
+}
+
+func (SQLiteDialect) LimitOffset(limit, offset int64) string {
+	return formatLimitOffset(limit, offset)
+}
+
+func (SQLiteDialect) BytesTypeName(size int) string { return "BLOB" }
+
+func (SQLiteDialect) StringTypeName(size int) string { return "TEXT" }
+
+func (SQLiteDialect) DateTimeTypeName() string { return "DATETIME" }
+
+func (SQLiteDialect) IntegerTypeName() string { return "INTEGER" }
+
+func (SQLiteDialect) DoubleTypeName(precision, scale int) string { return "REAL" }
+
+func (SQLiteDialect) DecimalTypeName(precision, scale int) string {
+	return formatPrecisionType("NUMERIC", precision, scale)
+}
+
+func (SQLiteDialect) BooleanTypeName() string { return "BOOLEAN" }
+
+func (SQLiteDialect) AutoIncrementKeyword() string { return "AUTOINCREMENT" }
+
+func (SQLiteDialect) IntegerAutoIncrementTypeName() string { return "" }
+
+func (SQLiteDialect) JSONTypeName() string { return "TEXT" }
+
+func (SQLiteDialect) UUIDTypeName() string { return "TEXT" }
+
+func (SQLiteDialect) UUIDLiteral(id []byte) string {
+	return "'" + uuidStringFromBytes(id) + "'"
+}
+
+func (SQLiteDialect) ArrayTypeName(elementType string) string {
+	// SQLite has no native array type; callers typically fall back to a
+	// JSON column instead.
+	return "TEXT"
+}
+
+func (SQLiteDialect) JSONExtract(columnSQL, path string) string {
+	return "JSON_EXTRACT(" + columnSQL + ", '" + path + "')"
+}
+
+func (SQLiteDialect) IsReservedWord(name string) bool {
+	return sqliteReservedWords[strings.ToLower(name)]
+}
+
+// sqliteReservedWords is a representative (not exhaustive) set of
+// SQLite reserved words that would otherwise silently produce broken
+// DDL if used bare as an identifier.
+var sqliteReservedWords = map[string]bool{
+	"select": true, "insert": true, "update": true, "delete": true,
+	"from": true, "where": true, "table": true, "index": true,
+	"key": true, "primary": true, "order": true, "group": true,
+	"by": true, "limit": true, "column": true,
+}
+
+// uuidStringFromBytes renders id (a normalized 16-byte UUID value, see
+// NormalizeUUIDValue) in canonical "xxxxxxxx-xxxx-..." form.
+func uuidStringFromBytes(id []byte) string {
+	parsed, err := uuid.FromBytes(id)
+	if err != nil {
+		// NormalizeUUIDValue already guarantees id is exactly 16 bytes.
+		panic(err)
+	}
+	return parsed.String()
+}
+
+func formatPrecisionType(name string, precision, scale int) string {
+	if precision <= 0 {
+		return name
+	}
+	return name + "(" + strconv.Itoa(precision) + "," + strconv.Itoa(scale) + ")"
+}
+
+// jsonPathToArrows turns a MySQL-style JSON path ("$.a.b") into the
+// equivalent chain of Postgres "->" operators ("->'a'->'b'").
+func jsonPathToArrows(path string) string {
+	path = strings.TrimPrefix(path, "$.")
+	path = strings.TrimPrefix(path, "$")
+	if path == "" {
+		return ""
+	}
+	var out strings.Builder
+	for _, part := range strings.Split(path, ".") {
+		out.WriteString("->'")
+		out.WriteString(part)
+		out.WriteString("'")
+	}
+	return out.String()
+}
+
+func formatLimitOffset(limit, offset int64) string {
+	s := "LIMIT " + strconv.FormatInt(limit, 10)
+	if offset >= 0 {
+		s += " OFFSET " + strconv.FormatInt(offset, 10)
+	}
+	return s
+}
+
+// encodeLiteral renders a Go value as a SQL literal, sharing logic that
+// is identical across dialects except for boolean spelling.
+func encodeLiteral(d Dialect, value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "NULL"
+	case bool:
+		return d.BooleanLiteral(v)
+	case string:
+		return "'" + strings.ReplaceAll(v, "'", "''") + "'"
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2015 CoreOS, Inc. All rights reserved.
+// Copyright 2014 Dropbox, Inc. All rights reserved.
+// Use of this source code is governed by the BSD 3-Clause license,
+// which can be found in the LICENSE file.
+
+package sqlbuilder
+
+import "testing"
+
+func buildUsersTable() *CreateTableStatement {
+	return NewCreateTableStatement("users").
+		AddColumn(MustIntColumn("id", NotNullable, AutoIncrement(), PrimaryKey())).
+		AddColumn(MustStrColumn("name", UTF8, UTF8Binary, NotNullable, Size(255))).
+		AddColumn(MustBoolColumn("active", NotNullable, Default(true)))
+}
+
+func TestCreateTableStatement_Dialects(t *testing.T) {
+	cases := []struct {
+		name    string
+		dialect Dialect
+		want    string
+	}{
+		{
+			name:    "mysql",
+			dialect: MySQL(),
+			want: "CREATE TABLE `users` (\n" +
+				"  `id` INTEGER NOT NULL AUTO_INCREMENT PRIMARY KEY,\n" +
+				"  `name` VARCHAR(255) CHARACTER SET utf8 COLLATE utf8_bin NOT NULL,\n" +
+				"  `active` TINYINT(1) NOT NULL DEFAULT 1\n" +
+				")",
+		},
+		{
+			name:    "postgres",
+			dialect: Postgres(),
+			want: "CREATE TABLE \"users\" (\n" +
+				"  \"id\" SERIAL NOT NULL PRIMARY KEY,\n" +
+				"  \"name\" VARCHAR(255) NOT NULL,\n" +
+				"  \"active\" BOOLEAN NOT NULL DEFAULT TRUE\n" +
+				")",
+		},
+		{
+			name:    "sqlite",
+			dialect: SQLite(),
+			want: "CREATE TABLE \"users\" (\n" +
+				"  \"id\" INTEGER NOT NULL AUTOINCREMENT PRIMARY KEY,\n" +
+				"  \"name\" TEXT NOT NULL,\n" +
+				"  \"active\" BOOLEAN NOT NULL DEFAULT 1\n" +
+				")",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := buildUsersTable().ToSql(c.dialect)
+			if err != nil {
+				t.Fatalf("ToSql returned error: %v", err)
+			}
+			if got != c.want {
+				t.Errorf("ToSql(%s) =\n%s\nwant:\n%s", c.name, got, c.want)
+			}
+		})
+	}
+}
+
+func TestDialectPlaceholder(t *testing.T) {
+	if got := MySQL().Placeholder(0); got != "?" {
+		t.Errorf("MySQL Placeholder(0) = %q, want \"?\"", got)
+	}
+	if got := SQLite().Placeholder(3); got != "?" {
+		t.Errorf("SQLite Placeholder(3) = %q, want \"?\"", got)
+	}
+	if got := Postgres().Placeholder(0); got != "$1" {
+		t.Errorf("Postgres Placeholder(0) = %q, want \"$1\"", got)
+	}
+	if got := Postgres().Placeholder(2); got != "$3" {
+		t.Errorf("Postgres Placeholder(2) = %q, want \"$3\"", got)
+	}
+}
+
+func TestLiteralEncodeEscapesQuotes(t *testing.T) {
+	got := MySQL().LiteralEncode("O'Brien")
+	want := "'O''Brien'"
+	if got != want {
+		t.Errorf("LiteralEncode(\"O'Brien\") = %q, want %q", got, want)
+	}
+}
+
+func TestDialectLimitOffset(t *testing.T) {
+	got := MySQL().LimitOffset(10, 20)
+	want := "LIMIT 10 OFFSET 20"
+	if got != want {
+		t.Errorf("LimitOffset(10, 20) = %q, want %q", got, want)
+	}
+
+	got = Postgres().LimitOffset(10, -1)
+	want = "LIMIT 10"
+	if got != want {
+		t.Errorf("LimitOffset(10, -1) = %q, want %q", got, want)
+	}
+}
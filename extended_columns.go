@@ -0,0 +1,224 @@
+// Copyright 2015 CoreOS, Inc. All rights reserved.
+// Copyright 2014 Dropbox, Inc. All rights reserved.
+// Use of this source code is governed by the BSD 3-Clause license,
+// which can be found in the LICENSE file.
+
+package sqlbuilder
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// exprFunc adapts a plain serialization function into an Expression,
+// for operators (JSONColumn.Extract, ArrayColumn.Contains, ...) that
+// don't need a dedicated column type of their own.
+type exprFunc struct {
+	isExpression
+	fn func(d Dialect, out *bytes.Buffer) error
+}
+
+func (e *exprFunc) SerializeSql(d Dialect, out *bytes.Buffer) error {
+	return e.fn(d, out)
+}
+
+type jsonColumn struct {
+	baseColumn
+	isExpression
+}
+
+// Representation of JSON/JSONB columns.
+// Returns an error if name is not a valid identifier.
+func JSONColumn(name string, nullable NullableColumn, options ...ColumnOption) (*jsonColumn, error) {
+	normalized, err := NormalizeIdentifier(name)
+	if err != nil {
+		return nil, err
+	}
+	jc := &jsonColumn{}
+	jc.name = normalized
+	jc.nullable = nullable
+	for _, opt := range options {
+		opt(&jc.baseColumn)
+	}
+	return jc, nil
+}
+
+// MustJSONColumn is like JSONColumn, but panics instead of returning an
+// error if name is not a valid identifier.
+func MustJSONColumn(name string, nullable NullableColumn, options ...ColumnOption) *jsonColumn {
+	col, err := JSONColumn(name, nullable, options...)
+	if err != nil {
+		panic(err)
+	}
+	return col
+}
+
+// SerializeColumnDefinition writes the DDL column definition for this
+// column (e.g. "`doc` JSON NOT NULL") to out.
+func (c *jsonColumn) SerializeColumnDefinition(d Dialect, out *bytes.Buffer) error {
+	return serializeColumnDefinition(&c.baseColumn, d.JSONTypeName(), d, out)
+}
+
+// Extract returns an expression for the value at the given JSON path
+// (e.g. "$.a.b"), rendered per-dialect: JSON_EXTRACT(`doc`, '$.a.b') on
+// MySQL/SQLite, "doc"->'a'->'b' on Postgres.
+func (c *jsonColumn) Extract(path string) Expression {
+	return &exprFunc{fn: func(d Dialect, out *bytes.Buffer) error {
+		var colBuf bytes.Buffer
+		if err := c.SerializeSql(d, &colBuf); err != nil {
+			return err
+		}
+		out.WriteString(d.JSONExtract(colBuf.String(), path))
+		return nil
+	}}
+}
+
+type uuidColumn struct {
+	baseColumn
+	isExpression
+}
+
+// Representation of UUID columns.
+// Returns an error if name is not a valid identifier.
+func UUIDColumn(name string, nullable NullableColumn, options ...ColumnOption) (*uuidColumn, error) {
+	normalized, err := NormalizeIdentifier(name)
+	if err != nil {
+		return nil, err
+	}
+	uc := &uuidColumn{}
+	uc.name = normalized
+	uc.nullable = nullable
+	for _, opt := range options {
+		opt(&uc.baseColumn)
+	}
+	return uc, nil
+}
+
+// MustUUIDColumn is like UUIDColumn, but panics instead of returning an
+// error if name is not a valid identifier.
+func MustUUIDColumn(name string, nullable NullableColumn, options ...ColumnOption) *uuidColumn {
+	col, err := UUIDColumn(name, nullable, options...)
+	if err != nil {
+		panic(err)
+	}
+	return col
+}
+
+// SerializeColumnDefinition writes the DDL column definition for this
+// column (e.g. "`id` BINARY(16) NOT NULL") to out.
+func (c *uuidColumn) SerializeColumnDefinition(d Dialect, out *bytes.Buffer) error {
+	return serializeColumnDefinition(&c.baseColumn, d.UUIDTypeName(), d, out)
+}
+
+// Eq returns a "col = <uuid literal>" expression, which may be a
+// uuid.UUID, a string, or []byte. The value is normalized via
+// NormalizeUUIDValue and encoded directly as a literal (via
+// Dialect.UUIDLiteral) rather than a bound placeholder, since this
+// package has no mechanism for threading a parameter's position back to
+// the caller.
+func (c *uuidColumn) Eq(value interface{}) Expression {
+	return &exprFunc{fn: func(d Dialect, out *bytes.Buffer) error {
+		id, err := NormalizeUUIDValue(value)
+		if err != nil {
+			return err
+		}
+		if err := c.SerializeSql(d, out); err != nil {
+			return err
+		}
+		out.WriteString(" = ")
+		out.WriteString(d.UUIDLiteral(id))
+		return nil
+	}}
+}
+
+// NormalizeUUIDValue converts a uuid.UUID, string, or []byte into the
+// normalized form that should be bound for a uuidColumn parameter: the
+// UUID's raw 16 bytes.
+func NormalizeUUIDValue(value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case uuid.UUID:
+		b := v
+		return b[:], nil
+	case string:
+		parsed, err := uuid.Parse(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid uuid string %q: %w", v, err)
+		}
+		return parsed[:], nil
+	case []byte:
+		if len(v) != 16 {
+			return nil, fmt.Errorf("invalid uuid bytes: want 16 bytes, got %d", len(v))
+		}
+		return v, nil
+	default:
+		return nil, fmt.Errorf("cannot normalize %T as a uuid", value)
+	}
+}
+
+type arrayColumn struct {
+	baseColumn
+	isExpression
+	elementType string
+}
+
+// Representation of a Postgres ARRAY column, e.g.
+// ArrayColumn("INTEGER", "tag_ids", NotNullable).
+// Returns an error if name is not a valid identifier.
+func ArrayColumn(elementType, name string, nullable NullableColumn, options ...ColumnOption) (*arrayColumn, error) {
+	normalized, err := NormalizeIdentifier(name)
+	if err != nil {
+		return nil, err
+	}
+	ac := &arrayColumn{elementType: elementType}
+	ac.name = normalized
+	ac.nullable = nullable
+	for _, opt := range options {
+		opt(&ac.baseColumn)
+	}
+	return ac, nil
+}
+
+// MustArrayColumn is like ArrayColumn, but panics instead of returning
+// an error if name is not a valid identifier.
+func MustArrayColumn(elementType, name string, nullable NullableColumn, options ...ColumnOption) *arrayColumn {
+	col, err := ArrayColumn(elementType, name, nullable, options...)
+	if err != nil {
+		panic(err)
+	}
+	return col
+}
+
+// SerializeColumnDefinition writes the DDL column definition for this
+// column (e.g. `"tag_ids" INTEGER[] NOT NULL`) to out. It returns an
+// error on non-Postgres dialects, which have no native array type.
+func (c *arrayColumn) SerializeColumnDefinition(d Dialect, out *bytes.Buffer) error {
+	if _, ok := d.(PostgresDialect); !ok {
+		return errors.New("ArrayColumn is only supported on the Postgres dialect")
+	}
+	return serializeColumnDefinition(&c.baseColumn, d.ArrayTypeName(c.elementType), d, out)
+}
+
+// Contains returns a "col @> ARRAY[...]" expression. It is Postgres-only
+// and returns an error when serialized against another dialect.
+func (c *arrayColumn) Contains(values ...interface{}) Expression {
+	return &exprFunc{fn: func(d Dialect, out *bytes.Buffer) error {
+		if _, ok := d.(PostgresDialect); !ok {
+			return errors.New("ArrayColumn.Contains is only supported on the Postgres dialect")
+		}
+		if err := c.SerializeSql(d, out); err != nil {
+			return err
+		}
+		out.WriteString(" @> ARRAY[")
+		for i, v := range values {
+			if i > 0 {
+				out.WriteString(", ")
+			}
+			out.WriteString(d.LiteralEncode(v))
+		}
+		out.WriteString("]")
+		return nil
+	}}
+}
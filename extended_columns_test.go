@@ -0,0 +1,111 @@
+// Copyright 2015 CoreOS, Inc. All rights reserved.
+// Copyright 2014 Dropbox, Inc. All rights reserved.
+// Use of this source code is governed by the BSD 3-Clause license,
+// which can be found in the LICENSE file.
+
+package sqlbuilder
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestJSONColumnExtract(t *testing.T) {
+	col := MustJSONColumn("doc", NotNullable)
+
+	var mysqlOut bytes.Buffer
+	if err := col.Extract("$.a.b").SerializeSql(MySQL(), &mysqlOut); err != nil {
+		t.Fatalf("SerializeSql returned error: %v", err)
+	}
+	if got, want := mysqlOut.String(), "JSON_EXTRACT(`doc`, '$.a.b')"; got != want {
+		t.Errorf("MySQL Extract = %q, want %q", got, want)
+	}
+
+	var pgOut bytes.Buffer
+	if err := col.Extract("$.a.b").SerializeSql(Postgres(), &pgOut); err != nil {
+		t.Fatalf("SerializeSql returned error: %v", err)
+	}
+	if got, want := pgOut.String(), `"doc"->'a'->'b'`; got != want {
+		t.Errorf("Postgres Extract = %q, want %q", got, want)
+	}
+}
+
+func TestNormalizeUUIDValue(t *testing.T) {
+	id := uuid.New()
+
+	b, err := NormalizeUUIDValue(id)
+	if err != nil {
+		t.Fatalf("NormalizeUUIDValue(uuid.UUID) returned error: %v", err)
+	}
+	if len(b) != 16 {
+		t.Errorf("len(NormalizeUUIDValue(uuid.UUID)) = %d, want 16", len(b))
+	}
+
+	b2, err := NormalizeUUIDValue(id.String())
+	if err != nil {
+		t.Fatalf("NormalizeUUIDValue(string) returned error: %v", err)
+	}
+	if !bytes.Equal(b, b2) {
+		t.Errorf("NormalizeUUIDValue(string) = %x, want %x", b2, b)
+	}
+
+	if _, err := NormalizeUUIDValue(42); err == nil {
+		t.Errorf("NormalizeUUIDValue(int) expected an error, got nil")
+	}
+}
+
+func TestUUIDColumnEq(t *testing.T) {
+	col := MustUUIDColumn("id", NotNullable)
+	id := uuid.MustParse("01234567-89ab-cdef-0123-456789abcdef")
+
+	var mysqlOut bytes.Buffer
+	if err := col.Eq(id).SerializeSql(MySQL(), &mysqlOut); err != nil {
+		t.Fatalf("SerializeSql returned error: %v", err)
+	}
+	if got, want := mysqlOut.String(), "`id` = X'0123456789abcdef0123456789abcdef'"; got != want {
+		t.Errorf("MySQL Eq = %q, want %q", got, want)
+	}
+
+	var pgOut bytes.Buffer
+	if err := col.Eq(id.String()).SerializeSql(Postgres(), &pgOut); err != nil {
+		t.Fatalf("SerializeSql returned error: %v", err)
+	}
+	if got, want := pgOut.String(), `"id" = '01234567-89ab-cdef-0123-456789abcdef'`; got != want {
+		t.Errorf("Postgres Eq = %q, want %q", got, want)
+	}
+
+	if err := col.Eq(42).SerializeSql(MySQL(), &bytes.Buffer{}); err == nil {
+		t.Errorf("Eq(42) expected an error, got nil")
+	}
+}
+
+func TestArrayColumnContainsRequiresPostgres(t *testing.T) {
+	col := MustArrayColumn("INTEGER", "tag_ids", NotNullable)
+
+	var pgOut bytes.Buffer
+	if err := col.Contains(1, 2, 3).SerializeSql(Postgres(), &pgOut); err != nil {
+		t.Fatalf("SerializeSql returned error: %v", err)
+	}
+	if got, want := pgOut.String(), `"tag_ids" @> ARRAY[1, 2, 3]`; got != want {
+		t.Errorf("Contains = %q, want %q", got, want)
+	}
+
+	var mysqlOut bytes.Buffer
+	if err := col.Contains(1).SerializeSql(MySQL(), &mysqlOut); err == nil {
+		t.Errorf("Contains on MySQL expected an error, got nil")
+	}
+}
+
+func TestArrayColumnContainsEscapesQuotes(t *testing.T) {
+	col := MustArrayColumn("TEXT", "tags", NotNullable)
+
+	var out bytes.Buffer
+	if err := col.Contains("a', 'b') OR ('1'='1").SerializeSql(Postgres(), &out); err != nil {
+		t.Fatalf("SerializeSql returned error: %v", err)
+	}
+	if got, want := out.String(), `"tags" @> ARRAY['a'', ''b'') OR (''1''=''1']`; got != want {
+		t.Errorf("Contains = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2015 CoreOS, Inc. All rights reserved.
+// Copyright 2014 Dropbox, Inc. All rights reserved.
+// Use of this source code is governed by the BSD 3-Clause license,
+// which can be found in the LICENSE file.
+
+package sqlbuilder
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// quotedIdentifierMarker distinguishes a Quoted(...) identifier from a
+// plain one. It's a control byte that can never appear in a normal Go
+// string literal someone would pass as a column/table name.
+const quotedIdentifierMarker = "\x00"
+
+// Quoted wraps name so that NormalizeIdentifier (and the constructors
+// built on it) treat it as an explicitly-quoted identifier: the usual
+// bare-identifier shape check is skipped, allowing spaces and
+// punctuation that would otherwise be rejected, e.g.
+// StrColumn(Quoted("weird name"), ...).
+func Quoted(name string) string {
+	return quotedIdentifierMarker + name
+}
+
+// NormalizeIdentifier validates name as a column/table identifier and
+// returns its normalized form (the identifier with any Quoted() marker
+// stripped). Plain identifiers must match the conservative
+// [a-zA-Z_]\w* shape; names built with Quoted(...) are passed through
+// unchanged, since the caller has already opted into needing explicit
+// quoting.
+//
+// This only validates shape: a single column/table is routinely reused
+// across several dialects (see CreateTableStatement), so the
+// dialect-specific parts - case folding and reserved-word rejection -
+// are applied per-dialect at serialization time, not once here at
+// construction time: folding by writeEscapedIdentifier (via
+// Dialect.FoldIdentifierCase) for every statement, and reserved-word
+// rejection by CreateTableStatement.ToSql (via Dialect.IsReservedWord)
+// for the table and column names only. Other statements do not check
+// reserved words, and a Quoted(...) name is checked like any other.
+func NormalizeIdentifier(name string) (normalized string, err error) {
+	if strings.HasPrefix(name, quotedIdentifierMarker) {
+		raw := strings.TrimPrefix(name, quotedIdentifierMarker)
+		if raw == "" {
+			return "", errors.New("Quoted(...) identifier must not be empty")
+		}
+		return raw, nil
+	}
+	if !validIdentifierRegexp.MatchString(name) {
+		return "", fmt.Errorf(
+			"invalid identifier `%s`; wrap it in Quoted(...) if it needs spaces or punctuation",
+			name)
+	}
+	return name, nil
+}
+
+// writeEscapedIdentifier writes name to out, case-folded per d's
+// FoldIdentifierCase rule and quoted with d's escape character. Every
+// column/table name in a serialized statement goes through here, which
+// is what makes a single Postgres-unquoted identifier like "Foo" come
+// out as the lowercased "foo" while the same column built once and
+// serialized against MySQL or SQLite keeps its original case.
+//
+// Any occurrence of the escape character itself within name is doubled
+// (the standard SQL quoted-identifier escape), so a Quoted(...) name
+// can't break out of its quoting: Quoted("x`; DROP TABLE users; --")
+// serializes on MySQL as the single, inert identifier
+// `` `x``; DROP TABLE users; --` `` rather than closing the quote early.
+func writeEscapedIdentifier(d Dialect, name string, out *bytes.Buffer) {
+	escape := d.EscapeCharacter()
+	out.WriteRune(escape)
+	out.WriteString(strings.ReplaceAll(d.FoldIdentifierCase(name), string(escape), string(escape)+string(escape)))
+	out.WriteRune(escape)
+}
@@ -0,0 +1,88 @@
+// Copyright 2015 CoreOS, Inc. All rights reserved.
+// Copyright 2014 Dropbox, Inc. All rights reserved.
+// Use of this source code is governed by the BSD 3-Clause license,
+// which can be found in the LICENSE file.
+
+package sqlbuilder
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestNormalizeIdentifier(t *testing.T) {
+	got, err := NormalizeIdentifier("user_id")
+	if err != nil {
+		t.Fatalf("NormalizeIdentifier(\"user_id\") returned error: %v", err)
+	}
+	if got != "user_id" {
+		t.Errorf("NormalizeIdentifier(\"user_id\") = %q, want \"user_id\"", got)
+	}
+
+	if _, err := NormalizeIdentifier("bad name!"); err == nil {
+		t.Errorf("NormalizeIdentifier(\"bad name!\") expected an error, got nil")
+	}
+
+	got, err = NormalizeIdentifier(Quoted("weird name!"))
+	if err != nil {
+		t.Fatalf("NormalizeIdentifier(Quoted(...)) returned error: %v", err)
+	}
+	if got != "weird name!" {
+		t.Errorf("NormalizeIdentifier(Quoted(\"weird name!\")) = %q, want \"weird name!\"", got)
+	}
+
+	if _, err := NormalizeIdentifier(Quoted("")); err == nil {
+		t.Errorf("NormalizeIdentifier(Quoted(\"\")) expected an error, got nil")
+	}
+}
+
+func TestColumnConstructorsRejectInvalidNames(t *testing.T) {
+	if _, err := IntColumn("not a valid name", NotNullable); err == nil {
+		t.Errorf("IntColumn with an invalid name expected an error, got nil")
+	}
+	if _, err := IntColumn("valid_name", NotNullable); err != nil {
+		t.Errorf("IntColumn with a valid name returned an unexpected error: %v", err)
+	}
+}
+
+func TestMustIntColumnPanicsOnInvalidName(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MustIntColumn with an invalid name expected a panic")
+		}
+	}()
+	MustIntColumn("not a valid name", NotNullable)
+}
+
+func TestWriteEscapedIdentifierEscapesEmbeddedEscapeChar(t *testing.T) {
+	col, err := StrColumn(Quoted("x`; DROP TABLE users; --"), UTF8, UTF8Binary, NotNullable)
+	if err != nil {
+		t.Fatalf("StrColumn returned error: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := col.SerializeSqlForColumnList(false, MySQL(), &out); err != nil {
+		t.Fatalf("SerializeSqlForColumnList returned error: %v", err)
+	}
+	if got, want := out.String(), "`x``; DROP TABLE users; --`"; got != want {
+		t.Errorf("SerializeSqlForColumnList = %q, want %q", got, want)
+	}
+}
+
+func TestCreateTableStatementRejectsReservedTableName(t *testing.T) {
+	table := NewCreateTableStatement("select").
+		AddColumn(MustIntColumn("id", NotNullable))
+	if _, err := table.ToSql(MySQL()); err == nil {
+		t.Errorf("CREATE TABLE `select` expected a reserved-word error, got nil")
+	}
+}
+
+func TestCreateTableStatementRejectsConflictingPrimaryKeyDeclarations(t *testing.T) {
+	idCol := MustIntColumn("id", NotNullable, PrimaryKey())
+	table := NewCreateTableStatement("users").
+		AddColumn(idCol).
+		AddPrimaryKey(idCol)
+	if _, err := table.ToSql(MySQL()); err == nil {
+		t.Errorf("PrimaryKey() combined with AddPrimaryKey expected an error, got nil")
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2015 CoreOS, Inc. All rights reserved.
+// Copyright 2014 Dropbox, Inc. All rights reserved.
+// Use of this source code is governed by the BSD 3-Clause license,
+// which can be found in the LICENSE file.
+
+package sqlbuilder
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+)
+
+// Scan reads the current row from rows into dst, a pointer to a struct
+// tagged as described by TableFromStruct. The caller must have already
+// advanced the cursor with rows.Next().
+func Scan(rows *sql.Rows, dst interface{}) error {
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	dstVal := reflect.ValueOf(dst)
+	if dstVal.Kind() != reflect.Ptr || dstVal.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("Scan requires a pointer to a struct, got %T", dst)
+	}
+
+	fields, err := fieldsByColumnName(dstVal.Elem().Type())
+	if err != nil {
+		return err
+	}
+
+	dest, err := scanTargets(dstVal.Elem(), columns, fields)
+	if err != nil {
+		return err
+	}
+	return rows.Scan(dest...)
+}
+
+// ScanAll reads every remaining row from rows into *slicePtr, a pointer
+// to a slice of structs (or pointers to structs) tagged as described by
+// TableFromStruct. It closes rows before returning, including on error.
+func ScanAll(rows *sql.Rows, slicePtr interface{}) error {
+	defer rows.Close()
+
+	sliceVal := reflect.ValueOf(slicePtr)
+	if sliceVal.Kind() != reflect.Ptr || sliceVal.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("ScanAll requires a pointer to a slice, got %T", slicePtr)
+	}
+	elemType := sliceVal.Elem().Type().Elem()
+	elemIsPtr := elemType.Kind() == reflect.Ptr
+	structType := elemType
+	if elemIsPtr {
+		structType = elemType.Elem()
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	fields, err := fieldsByColumnName(structType)
+	if err != nil {
+		return err
+	}
+
+	out := sliceVal.Elem()
+	for rows.Next() {
+		elemPtr := reflect.New(structType)
+		dest, err := scanTargets(elemPtr.Elem(), columns, fields)
+		if err != nil {
+			return err
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return err
+		}
+		if elemIsPtr {
+			out = reflect.Append(out, elemPtr)
+		} else {
+			out = reflect.Append(out, elemPtr.Elem())
+		}
+	}
+	sliceVal.Elem().Set(out)
+	return rows.Err()
+}
+
+// scanTargets returns the addressable struct fields that rows.Scan
+// should write into, in the same order as columns.
+func scanTargets(structVal reflect.Value, columns []string, fields map[string][]int) ([]interface{}, error) {
+	dest := make([]interface{}, len(columns))
+	for i, name := range columns {
+		index, ok := fields[name]
+		if !ok {
+			return nil, fmt.Errorf("no struct field tagged for column %q", name)
+		}
+		dest[i] = structVal.FieldByIndex(index).Addr().Interface()
+	}
+	return dest, nil
+}
+
+// fieldsByColumnName maps `sql:"..."` column names to the field index
+// path (supporting embedded structs) within t.
+func fieldsByColumnName(t reflect.Type) (map[string][]int, error) {
+	fields := make(map[string][]int)
+	if err := collectFields(t, nil, fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+func collectFields(t reflect.Type, prefix []int, fields map[string][]int) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		index := append(append([]int{}, prefix...), i)
+
+		if field.Anonymous {
+			ft := field.Type
+			for ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				if err := collectFields(ft, index, fields); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
+		rawTag, ok := field.Tag.Lookup("sql")
+		if !ok {
+			continue
+		}
+		tag, err := parseSqlTag(rawTag)
+		if err != nil {
+			return err
+		}
+		fields[tag.name] = index
+	}
+	return nil
+}
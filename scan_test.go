@@ -0,0 +1,231 @@
+// Copyright 2015 CoreOS, Inc. All rights reserved.
+// Copyright 2014 Dropbox, Inc. All rights reserved.
+// Use of this source code is governed by the BSD 3-Clause license,
+// which can be found in the LICENSE file.
+
+package sqlbuilder
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeRows is a minimal database/sql/driver.Rows backed by an in-memory
+// table, letting Scan/ScanAll be tested without a real database.
+type fakeRows struct {
+	cols []string
+	data [][]driver.Value
+	idx  int
+}
+
+func (r *fakeRows) Columns() []string { return r.cols }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.idx >= len(r.data) {
+		return io.EOF
+	}
+	copy(dest, r.data[r.idx])
+	r.idx++
+	return nil
+}
+
+type fakeConn struct{ rows *fakeRows }
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("fakeConn: Prepare not implemented")
+}
+func (c *fakeConn) Close() error              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) { return nil, errors.New("fakeConn: Begin not implemented") }
+
+func (c *fakeConn) Query(query string, args []driver.Value) (driver.Rows, error) {
+	return c.rows, nil
+}
+
+type fakeDriver struct{ conn *fakeConn }
+
+func (d fakeDriver) Open(name string) (driver.Conn, error) { return d.conn, nil }
+
+var fakeDriverCounter int64
+
+// openFakeRows registers a fresh driver backed by rows and returns a *sql.DB
+// that serves them for any query.
+func openFakeRows(t *testing.T, cols []string, data [][]driver.Value) *sql.DB {
+	t.Helper()
+	name := fmt.Sprintf("fakescan_%d", atomic.AddInt64(&fakeDriverCounter, 1))
+	sql.Register(name, fakeDriver{conn: &fakeConn{rows: &fakeRows{cols: cols, data: data}}})
+	db, err := sql.Open(name, "")
+	if err != nil {
+		t.Fatalf("sql.Open returned error: %v", err)
+	}
+	return db
+}
+
+type scanAddress struct {
+	City string `sql:"city"`
+}
+
+type scanUser struct {
+	scanAddress
+	ID        int64          `sql:"id"`
+	Name      string         `sql:"name"`
+	Bio       sql.NullString `sql:"bio"`
+	CreatedAt time.Time      `sql:"created_at"`
+}
+
+func TestScan(t *testing.T) {
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	db := openFakeRows(t,
+		[]string{"id", "name", "bio", "created_at", "city"},
+		[][]driver.Value{
+			{int64(1), "Ada", "Mathematician", created, "London"},
+		})
+	defer db.Close()
+
+	rows, err := db.Query("SELECT * FROM users")
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		t.Fatalf("expected a row, got none")
+	}
+
+	var u scanUser
+	if err := Scan(rows, &u); err != nil {
+		t.Fatalf("Scan returned error: %v", err)
+	}
+
+	if u.ID != 1 || u.Name != "Ada" || u.City != "London" {
+		t.Errorf("Scan = %+v, want ID=1 Name=Ada City=London", u)
+	}
+	if !u.Bio.Valid || u.Bio.String != "Mathematician" {
+		t.Errorf("Scan Bio = %+v, want valid \"Mathematician\"", u.Bio)
+	}
+	if !u.CreatedAt.Equal(created) {
+		t.Errorf("Scan CreatedAt = %v, want %v", u.CreatedAt, created)
+	}
+}
+
+func TestScanNullField(t *testing.T) {
+	db := openFakeRows(t,
+		[]string{"id", "name", "bio", "created_at", "city"},
+		[][]driver.Value{
+			{int64(2), "Grace", nil, time.Now(), "Arlington"},
+		})
+	defer db.Close()
+
+	rows, err := db.Query("SELECT * FROM users")
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		t.Fatalf("expected a row, got none")
+	}
+
+	var u scanUser
+	if err := Scan(rows, &u); err != nil {
+		t.Fatalf("Scan returned error: %v", err)
+	}
+	if u.Bio.Valid {
+		t.Errorf("Scan Bio.Valid = true for a NULL column, want false")
+	}
+}
+
+func TestScanRequiresPointerToStruct(t *testing.T) {
+	db := openFakeRows(t, []string{"id"}, [][]driver.Value{{int64(1)}})
+	defer db.Close()
+
+	rows, err := db.Query("SELECT * FROM users")
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		t.Fatalf("expected a row, got none")
+	}
+	var u scanUser
+	if err := Scan(rows, u); err == nil {
+		t.Errorf("Scan(non-pointer) expected an error, got nil")
+	}
+}
+
+func TestScanAll(t *testing.T) {
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	db := openFakeRows(t,
+		[]string{"id", "name", "bio", "created_at", "city"},
+		[][]driver.Value{
+			{int64(1), "Ada", "Mathematician", created, "London"},
+			{int64(2), "Grace", nil, created, "Arlington"},
+		})
+	defer db.Close()
+
+	rows, err := db.Query("SELECT * FROM users")
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+
+	var users []scanUser
+	if err := ScanAll(rows, &users); err != nil {
+		t.Fatalf("ScanAll returned error: %v", err)
+	}
+
+	if len(users) != 2 {
+		t.Fatalf("len(users) = %d, want 2", len(users))
+	}
+	if users[0].Name != "Ada" || !users[0].Bio.Valid {
+		t.Errorf("users[0] = %+v", users[0])
+	}
+	if users[1].Name != "Grace" || users[1].Bio.Valid {
+		t.Errorf("users[1] = %+v", users[1])
+	}
+}
+
+func TestScanAllPointerElements(t *testing.T) {
+	created := time.Now()
+	db := openFakeRows(t,
+		[]string{"id", "name", "bio", "created_at", "city"},
+		[][]driver.Value{
+			{int64(1), "Ada", "Mathematician", created, "London"},
+		})
+	defer db.Close()
+
+	rows, err := db.Query("SELECT * FROM users")
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+
+	var users []*scanUser
+	if err := ScanAll(rows, &users); err != nil {
+		t.Fatalf("ScanAll returned error: %v", err)
+	}
+	if len(users) != 1 || users[0].Name != "Ada" {
+		t.Errorf("ScanAll into []*scanUser = %+v", users)
+	}
+}
+
+func TestScanAllRequiresPointerToSlice(t *testing.T) {
+	db := openFakeRows(t, []string{"id"}, [][]driver.Value{{int64(1)}})
+	defer db.Close()
+
+	rows, err := db.Query("SELECT * FROM users")
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	defer rows.Close()
+
+	var notASlice scanUser
+	if err := ScanAll(rows, &notASlice); err == nil {
+		t.Errorf("ScanAll(non-slice) expected an error, got nil")
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright 2015 CoreOS, Inc. All rights reserved.
+// Copyright 2014 Dropbox, Inc. All rights reserved.
+// Use of this source code is governed by the BSD 3-Clause license,
+// which can be found in the LICENSE file.
+
+package sqlbuilder
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sqlTag is the parsed form of a `sql:"..."` struct tag, e.g.
+// `sql:"name,varchar(255),notnull"` or `sql:"id,pk,autoincrement"`.
+type sqlTag struct {
+	name          string
+	size          int
+	notNull       bool
+	null          bool
+	primaryKey    bool
+	autoIncrement bool
+}
+
+// parseSqlTag parses the contents of a `sql:"..."` struct tag.
+func parseSqlTag(tag string) (sqlTag, error) {
+	parts := strings.Split(tag, ",")
+	if len(parts) == 0 || parts[0] == "" {
+		return sqlTag{}, fmt.Errorf("sql tag is missing a column name: %q", tag)
+	}
+
+	t := sqlTag{name: parts[0]}
+	for _, opt := range parts[1:] {
+		switch {
+		case opt == "pk":
+			t.primaryKey = true
+		case opt == "autoincrement":
+			t.autoIncrement = true
+		case opt == "notnull":
+			t.notNull = true
+		case opt == "null":
+			t.null = true
+		case strings.HasPrefix(opt, "varchar(") && strings.HasSuffix(opt, ")"):
+			size, err := strconv.Atoi(opt[len("varchar(") : len(opt)-1])
+			if err != nil {
+				return sqlTag{}, fmt.Errorf("invalid varchar size in sql tag %q: %v", tag, err)
+			}
+			t.size = size
+		default:
+			return sqlTag{}, fmt.Errorf("unrecognized sql tag option %q in %q", opt, tag)
+		}
+	}
+	return t, nil
+}
+
+// columnFromField builds a NonAliasColumn for a single struct field,
+// inferring the column type from the field's Go type and applying the
+// attributes given by its `sql` tag.
+func columnFromField(field reflect.StructField) (NonAliasColumn, bool, error) {
+	rawTag, ok := field.Tag.Lookup("sql")
+	if !ok {
+		return nil, false, nil
+	}
+
+	tag, err := parseSqlTag(rawTag)
+	if err != nil {
+		return nil, false, err
+	}
+
+	nullable := NotNullable
+	fieldType := field.Type
+	if fieldType.Kind() == reflect.Ptr {
+		nullable = Nullable
+		fieldType = fieldType.Elem()
+	}
+	if tag.null {
+		nullable = Nullable
+	}
+	if tag.notNull {
+		nullable = NotNullable
+	}
+
+	var options []ColumnOption
+	if tag.primaryKey {
+		options = append(options, PrimaryKey())
+	}
+	if tag.autoIncrement {
+		options = append(options, AutoIncrement())
+	}
+
+	var col NonAliasColumn
+	switch {
+	case fieldType == reflect.TypeOf(time.Time{}):
+		col, err = DateTimeColumn(tag.name, nullable, options...)
+	case fieldType == reflect.TypeOf(sql.NullString{}):
+		col, err = StrColumn(tag.name, UTF8, UTF8Binary, Nullable, appendSize(options, tag.size)...)
+	case fieldType == reflect.TypeOf(sql.NullInt64{}):
+		col, err = IntColumn(tag.name, Nullable, options...)
+	case fieldType == reflect.TypeOf(sql.NullFloat64{}):
+		col, err = DoubleColumn(tag.name, Nullable, options...)
+	case fieldType == reflect.TypeOf(sql.NullBool{}):
+		col, err = BoolColumn(tag.name, Nullable, options...)
+	case fieldType.Kind() == reflect.String:
+		col, err = StrColumn(tag.name, UTF8, UTF8Binary, nullable, appendSize(options, tag.size)...)
+	case fieldType.Kind() == reflect.Bool:
+		col, err = BoolColumn(tag.name, nullable, options...)
+	case fieldType.Kind() == reflect.Float32 || fieldType.Kind() == reflect.Float64:
+		col, err = DoubleColumn(tag.name, nullable, options...)
+	case fieldType.Kind() == reflect.Slice && fieldType.Elem().Kind() == reflect.Uint8:
+		col, err = BytesColumn(tag.name, nullable, options...)
+	case isIntKind(fieldType.Kind()):
+		col, err = IntColumn(tag.name, nullable, options...)
+	default:
+		return nil, false, fmt.Errorf("field %s: no column mapping for type %s", field.Name, field.Type)
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("field %s: %w", field.Name, err)
+	}
+	return col, true, nil
+}
+
+func appendSize(options []ColumnOption, size int) []ColumnOption {
+	if size <= 0 {
+		return options
+	}
+	return append(options, Size(size))
+}
+
+func isIntKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+// TableFromStruct builds a *Table named `name` from the `sql`-tagged
+// fields of v (a struct or pointer to struct), e.g.:
+//
+//	type User struct {
+//	    ID   int64  `sql:"id,pk,autoincrement"`
+//	    Name string `sql:"name,varchar(255),notnull"`
+//	}
+//	table, err := TableFromStruct("users", User{})
+//
+// Embedded (anonymous) structs are flattened into the table's column
+// list. Fields without a `sql` tag are ignored.
+func TableFromStruct(name string, v interface{}) (*Table, error) {
+	if _, err := NormalizeIdentifier(name); err != nil {
+		return nil, fmt.Errorf("TableFromStruct: %w", err)
+	}
+
+	cols, err := columnsFromStruct(reflect.TypeOf(v))
+	if err != nil {
+		return nil, err
+	}
+	if len(cols) == 0 {
+		return nil, fmt.Errorf("TableFromStruct(%q): no `sql`-tagged fields found", name)
+	}
+
+	seen := make(map[string]bool, len(cols))
+	for _, col := range cols {
+		if seen[col.Name()] {
+			return nil, fmt.Errorf("TableFromStruct(%q): duplicate column name %q", name, col.Name())
+		}
+		seen[col.Name()] = true
+	}
+
+	return NewTable(name, cols...), nil
+}
+
+func columnsFromStruct(t reflect.Type) ([]NonAliasColumn, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("TableFromStruct requires a struct, got %s", t)
+	}
+
+	var cols []NonAliasColumn
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if field.Anonymous {
+			embedded, err := columnsFromStruct(field.Type)
+			if err != nil {
+				return nil, err
+			}
+			cols = append(cols, embedded...)
+			continue
+		}
+
+		col, ok, err := columnFromField(field)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			cols = append(cols, col)
+		}
+	}
+	return cols, nil
+}
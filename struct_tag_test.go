@@ -0,0 +1,92 @@
+// Copyright 2015 CoreOS, Inc. All rights reserved.
+// Copyright 2014 Dropbox, Inc. All rights reserved.
+// Use of this source code is governed by the BSD 3-Clause license,
+// which can be found in the LICENSE file.
+
+package sqlbuilder
+
+import (
+	"testing"
+	"time"
+)
+
+type testUser struct {
+	ID        int64     `sql:"id,pk,autoincrement"`
+	Name      string    `sql:"name,varchar(255),notnull"`
+	Bio       string    `sql:"bio,null"`
+	CreatedAt time.Time `sql:"created_at,notnull"`
+	Ignored   string
+}
+
+func TestTableFromStruct(t *testing.T) {
+	table, err := TableFromStruct("users", testUser{})
+	if err != nil {
+		t.Fatalf("TableFromStruct returned error: %v", err)
+	}
+
+	if table.Name() != "users" {
+		t.Errorf("table.Name() = %q, want \"users\"", table.Name())
+	}
+
+	cols := table.Columns()
+	if len(cols) != 4 {
+		t.Fatalf("len(table.Columns()) = %d, want 4", len(cols))
+	}
+
+	names := make([]string, len(cols))
+	for i, c := range cols {
+		names[i] = c.Name()
+	}
+	want := []string{"id", "name", "bio", "created_at"}
+	for i, w := range want {
+		if names[i] != w {
+			t.Errorf("cols[%d].Name() = %q, want %q", i, names[i], w)
+		}
+	}
+
+	if _, err := table.getColumn("id"); err != nil {
+		t.Errorf("getColumn(\"id\") returned error: %v", err)
+	}
+	if _, err := table.getColumn("missing"); err == nil {
+		t.Errorf("getColumn(\"missing\") expected an error, got nil")
+	}
+}
+
+func TestTableFromStructNoTaggedFields(t *testing.T) {
+	type empty struct {
+		X string
+	}
+	if _, err := TableFromStruct("empty", empty{}); err == nil {
+		t.Errorf("TableFromStruct with no tagged fields expected an error, got nil")
+	}
+}
+
+func TestTableFromStructRejectsDuplicateColumnName(t *testing.T) {
+	type dup struct {
+		A string `sql:"name,notnull"`
+		B string `sql:"name,notnull"`
+	}
+	if _, err := TableFromStruct("dups", dup{}); err == nil {
+		t.Errorf("TableFromStruct with duplicate column names expected an error, got nil")
+	}
+}
+
+func TestTableFromStructRejectsInvalidTableName(t *testing.T) {
+	if _, err := TableFromStruct("bad name!", testUser{}); err == nil {
+		t.Errorf("TableFromStruct with an invalid table name expected an error, got nil")
+	}
+}
+
+func TestParseSqlTag(t *testing.T) {
+	tag, err := parseSqlTag("name,varchar(255),notnull")
+	if err != nil {
+		t.Fatalf("parseSqlTag returned error: %v", err)
+	}
+	if tag.name != "name" || tag.size != 255 || !tag.notNull {
+		t.Errorf("parseSqlTag(\"name,varchar(255),notnull\") = %+v", tag)
+	}
+
+	if _, err := parseSqlTag("name,bogus"); err == nil {
+		t.Errorf("parseSqlTag with unrecognized option expected an error, got nil")
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2015 CoreOS, Inc. All rights reserved.
+// Copyright 2014 Dropbox, Inc. All rights reserved.
+// Use of this source code is governed by the BSD 3-Clause license,
+// which can be found in the LICENSE file.
+
+package sqlbuilder
+
+import "fmt"
+
+// Table represents a materialized SQL table and its columns. It is the
+// anchor that NonAliasColumn.setTableName and deferredLookupColumn
+// resolve against.
+type Table struct {
+	name          string
+	columns       []NonAliasColumn
+	columnsByName map[string]NonAliasColumn
+}
+
+// NewTable returns a Table with the given name and columns. It panics if
+// the table name is invalid or two columns share a name.
+func NewTable(name string, columns ...NonAliasColumn) *Table {
+	if !validIdentifierName(name) {
+		panic("Invalid table name: " + name)
+	}
+
+	t := &Table{
+		name:          name,
+		columns:       columns,
+		columnsByName: make(map[string]NonAliasColumn, len(columns)),
+	}
+	for _, col := range columns {
+		if _, dup := t.columnsByName[col.Name()]; dup {
+			panic("Duplicate column name in table " + name + ": " + col.Name())
+		}
+		if err := col.setTableName(name); err != nil {
+			panic(err)
+		}
+		t.columnsByName[col.Name()] = col
+	}
+	return t
+}
+
+// Name returns the table's name.
+func (t *Table) Name() string {
+	return t.name
+}
+
+// Columns returns the table's columns in declaration order.
+func (t *Table) Columns() []NonAliasColumn {
+	return t.columns
+}
+
+// C returns a deferred-lookup reference to one of the table's columns by
+// name, for use before the table's columns have otherwise been resolved.
+func (t *Table) C(name string) NonAliasColumn {
+	return &deferredLookupColumn{table: t, colName: name}
+}
+
+// getColumn returns the named column, or an error if no such column
+// exists on this table.
+func (t *Table) getColumn(name string) (NonAliasColumn, error) {
+	col, ok := t.columnsByName[name]
+	if !ok {
+		return nil, fmt.Errorf("no such column `%s` on table `%s`", name, t.name)
+	}
+	return col, nil
+}